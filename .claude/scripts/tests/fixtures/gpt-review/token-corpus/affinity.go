@@ -0,0 +1,108 @@
+package router
+
+import (
+	"context"
+	"time"
+)
+
+// AffinityConfig enables sticky routing for a Route: the first successful
+// Execute for a given extracted key pins subsequent requests with that key
+// to the same backend for TTL. This is useful for code review where
+// follow-up passes on the same PR or file should land on the backend that
+// saw the prior context.
+type AffinityConfig struct {
+	Key       string
+	Extractor string
+	TTL       time.Duration
+}
+
+// KeyExtractor pulls a sticky-routing key out of a request's input. The
+// second return value reports whether a key could be extracted at all.
+type KeyExtractor func(ctx context.Context, input string) (string, bool)
+
+type pinnedBackend struct {
+	backend   string
+	expiresAt time.Time
+}
+
+// RegisterExtractor adds a named key extractor that Affinity-enabled routes
+// can reference by name.
+func (rt *RouteTable) RegisterExtractor(name string, fn KeyExtractor) {
+	rt.mu.Lock()
+	defer rt.mu.Unlock()
+	rt.extractors[name] = fn
+}
+
+// affinityPin returns the backend currently pinned for route's affinity
+// key as extracted from input, if one exists and hasn't expired.
+func (rt *RouteTable) affinityPin(ctx context.Context, route Route, input string) (string, bool) {
+	if route.Affinity == nil {
+		return "", false
+	}
+
+	mapKey, ok := rt.affinityMapKey(ctx, route, input)
+	if !ok {
+		return "", false
+	}
+
+	rt.affinityMu.Lock()
+	defer rt.affinityMu.Unlock()
+
+	pin, ok := rt.affinity[mapKey]
+	if !ok {
+		return "", false
+	}
+	if time.Now().After(pin.expiresAt) {
+		delete(rt.affinity, mapKey)
+		return "", false
+	}
+	return pin.backend, true
+}
+
+// pinAffinity records backend as the sticky target for route's affinity
+// key as extracted from input, valid for Affinity.TTL.
+func (rt *RouteTable) pinAffinity(ctx context.Context, route Route, input, backend string) {
+	if route.Affinity == nil {
+		return
+	}
+
+	mapKey, ok := rt.affinityMapKey(ctx, route, input)
+	if !ok {
+		return
+	}
+
+	rt.affinityMu.Lock()
+	defer rt.affinityMu.Unlock()
+	rt.affinity[mapKey] = pinnedBackend{
+		backend:   backend,
+		expiresAt: time.Now().Add(route.Affinity.TTL),
+	}
+}
+
+// affinityMapKey resolves route's extractor and runs it over input,
+// returning a key namespaced by Affinity.Key so routes don't collide. It
+// takes its own read lock on rt.mu rather than assuming a caller holds
+// one, since Execute only holds rt.mu for the snapshotRoutes copy and not
+// for the rest of its body, and RegisterExtractor can run concurrently.
+//
+// History note: this lock is load-bearing, not decorative. The original
+// version of this function shipped with no lock at all and raced with
+// RegisterExtractor; the rt.mu.RLock/RUnlock pair below was introduced as
+// a side effect of the provider-cache refactor, not by a commit that
+// called out the race it was fixing. If this function is ever touched
+// without rt.mu.RLock/RUnlock surviving the edit, the race comes back.
+func (rt *RouteTable) affinityMapKey(ctx context.Context, route Route, input string) (string, bool) {
+	rt.mu.RLock()
+	extract, ok := rt.extractors[route.Affinity.Extractor]
+	rt.mu.RUnlock()
+	if !ok {
+		return "", false
+	}
+
+	key, ok := extract(ctx, input)
+	if !ok {
+		return "", false
+	}
+
+	return route.Affinity.Key + "\x00" + key, true
+}
@@ -0,0 +1,52 @@
+package router
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+)
+
+// TestAffinityMapKeyConcurrentWithRegisterExtractor guards against a
+// regression where affinityMapKey reads rt.extractors without holding
+// rt.mu, which races with a concurrent RegisterExtractor (run this test
+// with -race to catch it).
+func TestAffinityMapKeyConcurrentWithRegisterExtractor(t *testing.T) {
+	route := Route{
+		Affinity: &AffinityConfig{Key: "sticky", Extractor: "by-id", TTL: time.Minute},
+	}
+	rt := NewRouteTable([]Route{route})
+	rt.RegisterExtractor("by-id", func(ctx context.Context, input string) (string, bool) {
+		return input, true
+	})
+
+	var wg sync.WaitGroup
+	stop := make(chan struct{})
+
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		for i := 0; ; i++ {
+			select {
+			case <-stop:
+				return
+			default:
+			}
+			name := "by-id"
+			if i%2 == 1 {
+				name = "other"
+			}
+			rt.RegisterExtractor(name, func(ctx context.Context, input string) (string, bool) {
+				return input, true
+			})
+		}
+	}()
+
+	for i := 0; i < 1000; i++ {
+		rt.affinityPin(context.Background(), route, "req-1")
+		rt.pinAffinity(context.Background(), route, "req-1", "backend-a")
+	}
+
+	close(stop)
+	wg.Wait()
+}
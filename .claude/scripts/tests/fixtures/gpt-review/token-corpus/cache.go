@@ -0,0 +1,101 @@
+package router
+
+import (
+	"fmt"
+	"time"
+)
+
+// CacheKeyFunc derives a ResultCache key from a backend's input, e.g. a
+// hash of the diff being reviewed so repeat runs over the same change hit
+// the cache.
+type CacheKeyFunc func(input string) string
+
+// ResultCache stores ReviewResults for Cacheable routes, keyed by
+// CacheKeyFunc. Implementations own their own TTL expiry.
+type ResultCache interface {
+	Get(key string) (*ReviewResult, bool)
+	Set(key string, result *ReviewResult, ttl time.Duration)
+	Invalidate(key string)
+}
+
+// SetResultCache installs the cache and key function Cacheable routes use.
+// Both must be set for caching to take effect.
+func (rt *RouteTable) SetResultCache(cache ResultCache, keyFunc CacheKeyFunc) {
+	rt.mu.Lock()
+	defer rt.mu.Unlock()
+	rt.cache = cache
+	rt.cacheKeyFunc = keyFunc
+}
+
+// InvalidateCache removes any cached result for input, across every
+// Cacheable route. Cache entries are namespaced per route (see
+// routeCacheKey) so this invalidates each route's entry individually
+// rather than a single shared key.
+func (rt *RouteTable) InvalidateCache(input string) {
+	rt.mu.RLock()
+	cache, keyFunc, routes := rt.cache, rt.cacheKeyFunc, rt.routes
+	rt.mu.RUnlock()
+	if cache == nil || keyFunc == nil {
+		return
+	}
+	for i := range routes {
+		cache.Invalidate(routeCacheKey(i, keyFunc, input))
+	}
+}
+
+// routeCacheKey namespaces keyFunc(input) by routeIndex so two different
+// Cacheable routes that see the same input (e.g. a fallback route reached
+// via different Conditions) don't read or overwrite each other's cached
+// result.
+func routeCacheKey(routeIndex int, keyFunc CacheKeyFunc, input string) string {
+	return fmt.Sprintf("%d\x00%s", routeIndex, keyFunc(input))
+}
+
+// cacheLookup returns a cached result for routeIndex/route and input, if
+// route is Cacheable and one exists.
+func (rt *RouteTable) cacheLookup(routeIndex int, route Route, input string) (*ReviewResult, bool) {
+	if !route.Cacheable {
+		return nil, false
+	}
+
+	rt.mu.RLock()
+	cache, keyFunc := rt.cache, rt.cacheKeyFunc
+	rt.mu.RUnlock()
+	if cache == nil || keyFunc == nil {
+		return nil, false
+	}
+
+	return cache.Get(routeCacheKey(routeIndex, keyFunc, input))
+}
+
+// cacheStore saves result for routeIndex/route and input if route is
+// Cacheable and result's verdict is allowed to be cached.
+func (rt *RouteTable) cacheStore(routeIndex int, route Route, input string, result *ReviewResult) {
+	if !route.Cacheable || result == nil || !cacheableVerdict(route, result.Verdict) {
+		return
+	}
+
+	rt.mu.RLock()
+	cache, keyFunc := rt.cache, rt.cacheKeyFunc
+	rt.mu.RUnlock()
+	if cache == nil || keyFunc == nil {
+		return
+	}
+
+	cache.Set(routeCacheKey(routeIndex, keyFunc, input), result, route.CacheTTL)
+}
+
+// cacheableVerdict reports whether v may be cached for route. Routes that
+// don't override CacheableVerdicts never cache DECISION_NEEDED, since a
+// result that needs a human decision shouldn't be silently replayed.
+func cacheableVerdict(route Route, v Verdict) bool {
+	if route.CacheableVerdicts != nil {
+		for _, allowed := range route.CacheableVerdicts {
+			if allowed == v {
+				return true
+			}
+		}
+		return false
+	}
+	return v != VerdictDecisionNeeded
+}
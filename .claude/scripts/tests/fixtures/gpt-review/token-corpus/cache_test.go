@@ -0,0 +1,55 @@
+package router
+
+import (
+	"testing"
+	"time"
+)
+
+// memCache is a minimal in-process ResultCache for tests; it ignores TTL.
+type memCache struct {
+	entries map[string]*ReviewResult
+}
+
+func newMemCache() *memCache {
+	return &memCache{entries: make(map[string]*ReviewResult)}
+}
+
+func (c *memCache) Get(key string) (*ReviewResult, bool) {
+	r, ok := c.entries[key]
+	return r, ok
+}
+
+func (c *memCache) Set(key string, result *ReviewResult, ttl time.Duration) {
+	c.entries[key] = result
+}
+
+func (c *memCache) Invalidate(key string) {
+	delete(c.entries, key)
+}
+
+func TestCacheDoesNotCollideAcrossRoutes(t *testing.T) {
+	routes := []Route{
+		{Backend: "a", Cacheable: true},
+		{Backend: "b", Cacheable: true},
+	}
+	rt := NewRouteTable(routes)
+
+	sameKeyFunc := func(input string) string { return "same-key" }
+	rt.SetResultCache(newMemCache(), sameKeyFunc)
+
+	resultA := &ReviewResult{Verdict: VerdictApproved, Summary: "from a"}
+	resultB := &ReviewResult{Verdict: VerdictChangesReq, Summary: "from b"}
+
+	rt.cacheStore(0, routes[0], "same-input", resultA)
+	rt.cacheStore(1, routes[1], "same-input", resultB)
+
+	gotA, ok := rt.cacheLookup(0, routes[0], "same-input")
+	if !ok || gotA.Summary != "from a" {
+		t.Fatalf("route 0 lookup = %+v, %v; want resultA", gotA, ok)
+	}
+
+	gotB, ok := rt.cacheLookup(1, routes[1], "same-input")
+	if !ok || gotB.Summary != "from b" {
+		t.Fatalf("route 1 lookup = %+v, %v; want resultB", gotB, ok)
+	}
+}
@@ -0,0 +1,124 @@
+package router
+
+import (
+	"context"
+	"fmt"
+	"math/rand"
+	"time"
+)
+
+// renewalRetryBackoff is how soon a renewer retries after a failed fetch,
+// so a transient error doesn't strand a backend until the old credential
+// actually expires.
+const renewalRetryBackoff = 10 * time.Second
+
+// Credential is a value issued by a CredentialSource, such as a hosted LLM
+// API key, a Vault-issued token, or an OIDC-brokered access token.
+type Credential struct {
+	Value     string
+	Expiry    time.Time
+	Renewable bool
+}
+
+// CredentialSource fetches short-lived credentials for a backend.
+type CredentialSource interface {
+	Fetch(ctx context.Context) (Credential, error)
+}
+
+// RegisterBackendWithCredentials registers a backend whose credential is
+// short-lived: factory builds a BackendFunc from the current Credential,
+// and a background renewer refreshes it from src before it expires,
+// swapping the live BackendFunc in place. If the initial fetch fails,
+// RegisterBackendWithCredentials returns an error and no backend is
+// registered. The renewer exits when RouteTable.Stop is called.
+func (rt *RouteTable) RegisterBackendWithCredentials(name string, factory func(Credential) BackendFunc, src CredentialSource) error {
+	cred, err := src.Fetch(context.Background())
+	if err != nil {
+		return fmt.Errorf("fetch initial credential for %s: %w", name, err)
+	}
+
+	rt.RegisterBackend(name, factory(cred))
+
+	if cred.Renewable {
+		rt.wg.Add(1)
+		go rt.runCredentialRenewer(name, factory, src, cred)
+	}
+	return nil
+}
+
+// runCredentialRenewer refreshes name's credential before it expires,
+// jittering the wait so many backends don't renew in lockstep. A failed
+// fetch is recorded via Inspect but otherwise ignored: the backend keeps
+// serving its current credential and the renewer retries sooner.
+func (rt *RouteTable) runCredentialRenewer(name string, factory func(Credential) BackendFunc, src CredentialSource, cred Credential) {
+	defer rt.wg.Done()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go func() {
+		select {
+		case <-rt.stopCh:
+			cancel()
+		case <-ctx.Done():
+		}
+	}()
+
+	for {
+		timer := time.NewTimer(renewalDelay(cred.Expiry))
+		select {
+		case <-rt.stopCh:
+			timer.Stop()
+			return
+		case <-timer.C:
+		}
+
+		next, err := src.Fetch(ctx)
+
+		select {
+		case <-rt.stopCh:
+			// Stop fired while Fetch was in flight: src.Fetch(ctx)
+			// has already returned (ctx was cancelled), so don't
+			// spin back around into another renewal attempt.
+			return
+		default:
+		}
+
+		bh := rt.getOrCreateHealth(name)
+		bh.mu.Lock()
+		bh.lastRenewalErr = err
+		bh.mu.Unlock()
+
+		if err != nil {
+			cred.Expiry = time.Now().Add(renewalRetryBackoff)
+			continue
+		}
+
+		rt.RegisterBackend(name, factory(next))
+		cred = next
+		if !cred.Renewable {
+			return
+		}
+	}
+}
+
+// renewalDelay picks when to renew ahead of expiry: 90% of the remaining
+// TTL, jittered by up to the remaining 10% so concurrent renewers spread
+// out instead of all refreshing at once.
+func renewalDelay(expiry time.Time) time.Duration {
+	remaining := time.Until(expiry)
+	if remaining <= 0 {
+		return 0
+	}
+
+	lead := remaining / 10
+	jitter := time.Duration(0)
+	if lead > 0 {
+		jitter = time.Duration(rand.Int63n(int64(lead)))
+	}
+
+	delay := remaining - lead - jitter
+	if delay < 0 {
+		delay = 0
+	}
+	return delay
+}
@@ -0,0 +1,53 @@
+package router
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+// blockingSource's Fetch hangs until its context is cancelled, simulating a
+// network partition to the credential backend (Vault, an OIDC provider,
+// etc.) that never responds.
+type blockingSource struct {
+	fetches int
+}
+
+func (s *blockingSource) Fetch(ctx context.Context) (Credential, error) {
+	s.fetches++
+	if s.fetches == 1 {
+		return Credential{Value: "initial", Expiry: time.Now().Add(time.Millisecond), Renewable: true}, nil
+	}
+	<-ctx.Done()
+	return Credential{}, ctx.Err()
+}
+
+func TestStopDoesNotHangWhenCredentialFetchBlocks(t *testing.T) {
+	rt := NewRouteTable(nil)
+	src := &blockingSource{}
+
+	err := rt.RegisterBackendWithCredentials("creds", func(c Credential) BackendFunc {
+		return func(ctx context.Context, input string) (*ReviewResult, error) {
+			return &ReviewResult{Verdict: VerdictApproved}, nil
+		}
+	}, src)
+	if err != nil {
+		t.Fatalf("RegisterBackendWithCredentials: %v", err)
+	}
+
+	// Give the renewer time to wake up after the 1ms initial expiry and
+	// land inside the blocking second Fetch call.
+	time.Sleep(20 * time.Millisecond)
+
+	done := make(chan struct{})
+	go func() {
+		rt.Stop()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("Stop() did not return while CredentialSource.Fetch was blocked")
+	}
+}
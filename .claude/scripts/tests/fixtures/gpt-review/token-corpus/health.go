@@ -0,0 +1,200 @@
+package router
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// HealthCheck configures active probing and circuit breaking for a route's
+// backend. A nil HealthCheck on a Route disables both: the backend is
+// always considered healthy and no supervisor goroutine is started for it.
+// A non-nil HealthCheck must set Interval and Timeout to positive
+// durations; RouteTable.Start validates this and returns an error rather
+// than starting any supervisor goroutine if it doesn't hold.
+type HealthCheck struct {
+	Interval           time.Duration
+	Timeout            time.Duration
+	UnhealthyThreshold int
+	HealthyThreshold   int
+	// BackendFunc, if set, is used for active probes instead of the
+	// route's registered backend. This lets a probe hit a lightweight
+	// "/ping"-style endpoint rather than running a full review.
+	BackendFunc BackendFunc
+}
+
+// healthState is the circuit state for a single backend.
+type healthState int
+
+const (
+	stateHealthy healthState = iota
+	stateUnhealthy
+	stateHalfOpen
+)
+
+// backendHealth tracks consecutive probe/call outcomes for one backend, plus
+// its most recent credential-renewal outcome, if any.
+type backendHealth struct {
+	mu               sync.Mutex
+	state            healthState
+	consecFails      int
+	consecOK         int
+	lastErr          error
+	lastChecked      time.Time
+	halfOpenInFlight bool
+	lastRenewalErr   error
+}
+
+// BackendStatus is the externally visible health, circuit-breaker, and
+// credential-renewal state for a backend, as returned by RouteTable.Inspect.
+type BackendStatus struct {
+	Backend          string
+	Healthy          bool
+	ConsecutiveFails int
+	ConsecutiveOK    int
+	LastError        error
+	LastChecked      time.Time
+	LastRenewalError error
+}
+
+// Inspect returns the current health/circuit and credential-renewal status
+// of every backend that has a HealthCheck on at least one route or was
+// registered via RegisterBackendWithCredentials. Other backends never
+// appear here since they have no tracked state.
+func (rt *RouteTable) Inspect() map[string]BackendStatus {
+	rt.healthMu.Lock()
+	defer rt.healthMu.Unlock()
+
+	out := make(map[string]BackendStatus, len(rt.health))
+	for name, bh := range rt.health {
+		bh.mu.Lock()
+		out[name] = BackendStatus{
+			Backend:          name,
+			Healthy:          bh.state != stateUnhealthy,
+			ConsecutiveFails: bh.consecFails,
+			ConsecutiveOK:    bh.consecOK,
+			LastError:        bh.lastErr,
+			LastChecked:      bh.lastChecked,
+			LastRenewalError: bh.lastRenewalErr,
+		}
+		bh.mu.Unlock()
+	}
+	return out
+}
+
+// runHealthCheck periodically probes route's backend until the table is
+// stopped or ctx is cancelled.
+func (rt *RouteTable) runHealthCheck(ctx context.Context, route Route) {
+	defer rt.wg.Done()
+
+	hc := route.HealthCheck
+	ticker := time.NewTicker(hc.Interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-rt.stopCh:
+			return
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			rt.probeBackend(ctx, route.Backend, hc)
+		}
+	}
+}
+
+// probeBackend runs a single active health probe and feeds its outcome into
+// the same state machine Execute's live calls feed.
+func (rt *RouteTable) probeBackend(ctx context.Context, backend string, hc *HealthCheck) {
+	probe := hc.BackendFunc
+	if probe == nil {
+		probe, _ = rt.resolveBackend(ctx, backend)
+	}
+	if probe == nil {
+		return
+	}
+
+	probeCtx, cancel := context.WithTimeout(ctx, hc.Timeout)
+	_, err := probe(probeCtx, "")
+	cancel()
+
+	rt.recordResult(backend, hc, err)
+}
+
+// allowAttempt reports whether Execute may attempt to call backend right
+// now. A healthy backend is always allowed. An unhealthy backend allows
+// exactly one concurrent half-open probe through so it can recover; all
+// other callers are turned away until that probe resolves. A half-open
+// probe that succeeds but doesn't reach HealthyThreshold on its own falls
+// back to unhealthy (see recordResult) rather than leaving state stuck at
+// half-open, so the next caller is granted another probe instead of being
+// turned away forever.
+func (rt *RouteTable) allowAttempt(backend string, hc *HealthCheck) bool {
+	if hc == nil {
+		return true
+	}
+	bh := rt.getOrCreateHealth(backend)
+	bh.mu.Lock()
+	defer bh.mu.Unlock()
+
+	if bh.state == stateHealthy {
+		return true
+	}
+	if bh.halfOpenInFlight {
+		return false
+	}
+	bh.halfOpenInFlight = true
+	bh.state = stateHalfOpen
+	return true
+}
+
+// recordResult feeds a probe or live-call outcome into backend's circuit
+// breaker, tripping it open past UnhealthyThreshold consecutive failures
+// and closing it again after HealthyThreshold consecutive successes.
+func (rt *RouteTable) recordResult(backend string, hc *HealthCheck, err error) {
+	if hc == nil {
+		return
+	}
+	bh := rt.getOrCreateHealth(backend)
+	bh.mu.Lock()
+	defer bh.mu.Unlock()
+
+	bh.lastChecked = time.Now()
+	bh.halfOpenInFlight = false
+
+	if err != nil {
+		bh.lastErr = err
+		bh.consecOK = 0
+		bh.consecFails++
+		if bh.consecFails >= hc.UnhealthyThreshold || bh.state == stateHalfOpen {
+			bh.state = stateUnhealthy
+		}
+		return
+	}
+
+	bh.lastErr = nil
+	bh.consecFails = 0
+	bh.consecOK++
+	switch {
+	case bh.consecOK >= hc.HealthyThreshold:
+		bh.state = stateHealthy
+	case bh.state == stateHalfOpen:
+		// Not enough consecutive successes yet to fully close the
+		// breaker. Fall back to unhealthy instead of leaving state
+		// stuck at half-open, so allowAttempt grants another probe
+		// next time rather than denying every caller forever.
+		bh.state = stateUnhealthy
+	}
+}
+
+func (rt *RouteTable) getOrCreateHealth(backend string) *backendHealth {
+	rt.healthMu.Lock()
+	defer rt.healthMu.Unlock()
+
+	bh, ok := rt.health[backend]
+	if !ok {
+		bh = &backendHealth{state: stateHealthy}
+		rt.health[backend] = bh
+	}
+	return bh
+}
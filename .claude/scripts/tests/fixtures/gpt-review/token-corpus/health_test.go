@@ -0,0 +1,132 @@
+package router
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+// step is one call into the circuit breaker: either a probe admission
+// check (allow=true) or a result feed (allow=false, recordErr is the
+// outcome fed to recordResult).
+type step struct {
+	allow      bool
+	recordErr  error
+	wantAllow  bool
+	wantHealth healthState
+}
+
+func TestAllowAttemptRecordResultTransitions(t *testing.T) {
+	hc := &HealthCheck{UnhealthyThreshold: 2, HealthyThreshold: 2}
+	failErr := errors.New("boom")
+
+	cases := []struct {
+		name  string
+		steps []step
+	}{
+		{
+			name: "stays healthy on an isolated failure below threshold",
+			steps: []step{
+				{allow: true, wantAllow: true, wantHealth: stateHealthy},
+				{recordErr: failErr, wantHealth: stateHealthy},
+				{allow: true, wantAllow: true, wantHealth: stateHealthy},
+			},
+		},
+		{
+			name: "trips unhealthy after UnhealthyThreshold consecutive failures",
+			steps: []step{
+				{recordErr: failErr, wantHealth: stateHealthy},
+				{recordErr: failErr, wantHealth: stateUnhealthy},
+				{allow: true, wantAllow: true, wantHealth: stateHalfOpen},
+			},
+		},
+		{
+			name: "denies a second concurrent probe while one is in flight",
+			steps: []step{
+				{recordErr: failErr, wantHealth: stateHealthy},
+				{recordErr: failErr, wantHealth: stateUnhealthy},
+				{allow: true, wantAllow: true, wantHealth: stateHalfOpen},
+				{allow: true, wantAllow: false, wantHealth: stateHalfOpen},
+			},
+		},
+		{
+			name: "half-open failure re-trips unhealthy immediately",
+			steps: []step{
+				{recordErr: failErr, wantHealth: stateHealthy},
+				{recordErr: failErr, wantHealth: stateUnhealthy},
+				{allow: true, wantAllow: true, wantHealth: stateHalfOpen},
+				{recordErr: failErr, wantHealth: stateUnhealthy},
+				{allow: true, wantAllow: true, wantHealth: stateHalfOpen},
+			},
+		},
+		{
+			name: "half-open success below HealthyThreshold re-admits another probe instead of wedging",
+			steps: []step{
+				{recordErr: failErr, wantHealth: stateHealthy},
+				{recordErr: failErr, wantHealth: stateUnhealthy},
+				{allow: true, wantAllow: true, wantHealth: stateHalfOpen},
+				{recordErr: nil, wantHealth: stateUnhealthy},
+				{allow: true, wantAllow: true, wantHealth: stateHalfOpen},
+				{recordErr: nil, wantHealth: stateHealthy},
+			},
+		},
+		{
+			name: "fully healthy once HealthyThreshold reached admits every caller",
+			steps: []step{
+				{recordErr: failErr, wantHealth: stateHealthy},
+				{recordErr: failErr, wantHealth: stateUnhealthy},
+				{allow: true, wantAllow: true, wantHealth: stateHalfOpen},
+				{recordErr: nil, wantHealth: stateUnhealthy},
+				{allow: true, wantAllow: true, wantHealth: stateHalfOpen},
+				{recordErr: nil, wantHealth: stateHealthy},
+				{allow: true, wantAllow: true, wantHealth: stateHealthy},
+				{allow: true, wantAllow: true, wantHealth: stateHealthy},
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			rt := NewRouteTable(nil)
+			const backend = "b1"
+
+			for i, s := range tc.steps {
+				if s.allow {
+					if got := rt.allowAttempt(backend, hc); got != s.wantAllow {
+						t.Fatalf("step %d: allowAttempt() = %v, want %v", i, got, s.wantAllow)
+					}
+				} else {
+					rt.recordResult(backend, hc, s.recordErr)
+				}
+
+				bh := rt.getOrCreateHealth(backend)
+				bh.mu.Lock()
+				gotState := bh.state
+				bh.mu.Unlock()
+				if gotState != s.wantHealth {
+					t.Fatalf("step %d: state = %v, want %v", i, gotState, s.wantHealth)
+				}
+			}
+		})
+	}
+}
+
+func TestStartRejectsZeroHealthCheckIntervalOrTimeout(t *testing.T) {
+	cases := []struct {
+		name string
+		hc   *HealthCheck
+	}{
+		{name: "zero Interval", hc: &HealthCheck{UnhealthyThreshold: 2, HealthyThreshold: 2}},
+		{name: "zero Timeout", hc: &HealthCheck{Interval: time.Second, UnhealthyThreshold: 2, HealthyThreshold: 2}},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			rt := NewRouteTable([]Route{{Backend: "b1", HealthCheck: tc.hc}})
+			if err := rt.Start(context.Background()); err == nil {
+				t.Fatal("Start() = nil error, want a validation error")
+			}
+		})
+	}
+}
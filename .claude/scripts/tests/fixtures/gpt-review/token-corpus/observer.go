@@ -0,0 +1,57 @@
+package router
+
+import "time"
+
+// AttemptInfo describes a single backend call, successful or not.
+type AttemptInfo struct {
+	RouteIndex int
+	Backend    string
+	Attempt    int
+	Latency    time.Duration
+	Err        error
+}
+
+// Observer receives structured callbacks as Execute runs, replacing the
+// package's original ad-hoc fmt.Printf logging.
+type Observer interface {
+	// OnRouteStart fires once a route's backend has been chosen and is
+	// about to be attempted.
+	OnRouteStart(routeIndex int, backend string)
+	// OnAttempt fires after every individual backend call, successful or
+	// not.
+	OnAttempt(info AttemptInfo)
+	// OnRetry fires when an attempt failed and another is about to run.
+	OnRetry(routeIndex int, backend string, attempt int, err error)
+	// OnRouteResult fires once a route produces a result that Execute
+	// will return to its caller.
+	OnRouteResult(routeIndex int, backend string, result *ReviewResult)
+	// OnExhausted fires when a route's retries (or fan-out) are used up
+	// without success; failMode is the route's FailMode.
+	OnExhausted(routeIndex int, backend string, failMode string, err error)
+}
+
+// SetObserver installs the Observer Execute reports to. A nil Observer
+// (the default) disables reporting entirely.
+func (rt *RouteTable) SetObserver(o Observer) {
+	rt.mu.Lock()
+	defer rt.mu.Unlock()
+	rt.observer = o
+}
+
+func (rt *RouteTable) observerOrNoop() Observer {
+	rt.mu.RLock()
+	o := rt.observer
+	rt.mu.RUnlock()
+	if o == nil {
+		return noopObserver{}
+	}
+	return o
+}
+
+type noopObserver struct{}
+
+func (noopObserver) OnRouteStart(routeIndex int, backend string)                      {}
+func (noopObserver) OnAttempt(info AttemptInfo)                                       {}
+func (noopObserver) OnRetry(routeIndex int, backend string, attempt int, err error)    {}
+func (noopObserver) OnRouteResult(routeIndex int, backend string, result *ReviewResult) {}
+func (noopObserver) OnExhausted(routeIndex int, backend string, failMode string, err error) {}
@@ -0,0 +1,113 @@
+package router
+
+import (
+	"context"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// OTelObserver is a built-in Observer that emits one span per attempt plus
+// route.attempts, route.latency, and route.fail_mode.triggered
+// counters/histograms. Attach wires up an additional backend.health gauge
+// sourced from RouteTable.Inspect.
+type OTelObserver struct {
+	tracer trace.Tracer
+
+	attempts      metric.Int64Counter
+	latency       metric.Float64Histogram
+	failModeTrips metric.Int64Counter
+}
+
+// NewOTelObserver builds an OTelObserver, registering its instruments under
+// the "router" instrumentation scope.
+func NewOTelObserver(tp trace.TracerProvider, mp metric.MeterProvider) (*OTelObserver, error) {
+	meter := mp.Meter("router")
+
+	attempts, err := meter.Int64Counter("route.attempts",
+		metric.WithDescription("Number of backend call attempts"))
+	if err != nil {
+		return nil, err
+	}
+
+	latency, err := meter.Float64Histogram("route.latency",
+		metric.WithDescription("Backend call latency"),
+		metric.WithUnit("ms"))
+	if err != nil {
+		return nil, err
+	}
+
+	failModeTrips, err := meter.Int64Counter("route.fail_mode.triggered",
+		metric.WithDescription("Number of times a route's FailMode short-circuited Execute"))
+	if err != nil {
+		return nil, err
+	}
+
+	return &OTelObserver{
+		tracer:        tp.Tracer("router"),
+		attempts:      attempts,
+		latency:       latency,
+		failModeTrips: failModeTrips,
+	}, nil
+}
+
+// Attach registers a backend.health observable gauge, sourced from rt's
+// health/circuit-breaker state at collection time.
+func (o *OTelObserver) Attach(rt *RouteTable, mp metric.MeterProvider) error {
+	meter := mp.Meter("router")
+	gauge, err := meter.Int64ObservableGauge("backend.health",
+		metric.WithDescription("1 if a backend is currently healthy, 0 otherwise"))
+	if err != nil {
+		return err
+	}
+
+	_, err = meter.RegisterCallback(func(ctx context.Context, obs metric.Observer) error {
+		for name, status := range rt.Inspect() {
+			val := int64(0)
+			if status.Healthy {
+				val = 1
+			}
+			obs.ObserveInt64(gauge, val, metric.WithAttributes(attribute.String("backend", name)))
+		}
+		return nil
+	}, gauge)
+	return err
+}
+
+func (o *OTelObserver) OnRouteStart(routeIndex int, backend string) {}
+
+func (o *OTelObserver) OnAttempt(info AttemptInfo) {
+	end := time.Now()
+	attrs := []attribute.KeyValue{
+		attribute.Int("route.index", info.RouteIndex),
+		attribute.String("backend", info.Backend),
+		attribute.Int("attempt", info.Attempt),
+	}
+
+	_, span := o.tracer.Start(context.Background(), "router.attempt",
+		trace.WithTimestamp(end.Add(-info.Latency)),
+		trace.WithAttributes(attrs...))
+	if info.Err != nil {
+		span.RecordError(info.Err)
+	}
+	span.End(trace.WithTimestamp(end))
+
+	o.attempts.Add(context.Background(), 1, metric.WithAttributes(attrs...))
+	o.latency.Record(context.Background(), float64(info.Latency.Milliseconds()), metric.WithAttributes(attrs...))
+}
+
+func (o *OTelObserver) OnRetry(routeIndex int, backend string, attempt int, err error) {}
+
+func (o *OTelObserver) OnRouteResult(routeIndex int, backend string, result *ReviewResult) {}
+
+func (o *OTelObserver) OnExhausted(routeIndex int, backend string, failMode string, err error) {
+	if failMode != "hard_fail" {
+		return
+	}
+	o.failModeTrips.Add(context.Background(), 1, metric.WithAttributes(
+		attribute.Int("route.index", routeIndex),
+		attribute.String("backend", backend),
+	))
+}
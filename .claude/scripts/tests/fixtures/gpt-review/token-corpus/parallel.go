@@ -0,0 +1,164 @@
+package router
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+)
+
+// MergeFunc combines the successful ReviewResults from a parallel_all or
+// quorum route into the single result Execute returns.
+type MergeFunc func(results []*ReviewResult) *ReviewResult
+
+// verdictRank orders verdicts from least to most severe so a merge can pick
+// the most severe verdict across a set of results.
+var verdictRank = map[Verdict]int{
+	VerdictApproved:       0,
+	VerdictSkipped:        1,
+	VerdictDecisionNeeded: 2,
+	VerdictChangesReq:     3,
+}
+
+type fanOutOutcome struct {
+	backend string
+	result  *ReviewResult
+	err     error
+}
+
+// executeFanOut dispatches route's backend plus its SecondaryBackends
+// concurrently and merges the results according to route.Strategy.
+func (rt *RouteTable) executeFanOut(ctx context.Context, i int, route Route, input string) (*ReviewResult, error) {
+	routeCtx, cancel := context.WithTimeout(ctx, route.Timeout)
+	defer cancel()
+
+	names := append([]string{route.Backend}, route.SecondaryBackends...)
+	outcomes := make(chan fanOutOutcome, len(names))
+
+	var wg sync.WaitGroup
+	dispatched := 0
+	for _, name := range names {
+		fn, ok := rt.resolveBackend(routeCtx, name)
+		if !ok || !rt.allowAttempt(name, route.HealthCheck) {
+			continue
+		}
+		dispatched++
+		wg.Add(1)
+		go func(name string, fn BackendFunc) {
+			defer wg.Done()
+			result, err := fn(routeCtx, input)
+			rt.recordResult(name, route.HealthCheck, err)
+			select {
+			case outcomes <- fanOutOutcome{backend: name, result: result, err: err}:
+			case <-routeCtx.Done():
+			}
+		}(name, fn)
+	}
+
+	if dispatched == 0 {
+		return nil, fmt.Errorf("route %d: no backends available for strategy %s", i, route.Strategy)
+	}
+
+	go func() {
+		wg.Wait()
+		close(outcomes)
+	}()
+
+	merge := route.MergeFunc
+	if merge == nil {
+		merge = defaultMerge
+	}
+
+	obs := rt.observerOrNoop()
+	if route.Strategy == "quorum" {
+		return rt.collectQuorum(i, route, dispatched, outcomes, merge, obs)
+	}
+	return rt.collectAll(i, route, dispatched, outcomes, merge, obs)
+}
+
+// collectAll implements parallel_all: wait for every dispatched backend and
+// merge whichever of them succeeded.
+func (rt *RouteTable) collectAll(i int, route Route, dispatched int, outcomes <-chan fanOutOutcome, merge MergeFunc, obs Observer) (*ReviewResult, error) {
+	var results []*ReviewResult
+	for o := range outcomes {
+		obs.OnAttempt(AttemptInfo{RouteIndex: i, Backend: o.backend, Err: o.err})
+		if o.err != nil || o.result == nil {
+			continue
+		}
+		results = append(results, o.result)
+	}
+
+	if len(results) == 0 {
+		err := fmt.Errorf("route %d: all %d backends failed", i, dispatched)
+		obs.OnExhausted(i, route.Backend, route.FailMode, err)
+		return nil, err
+	}
+	result := merge(results)
+	obs.OnRouteResult(i, route.Backend, result)
+	return result, nil
+}
+
+// collectQuorum implements quorum: return as soon as QuorumSize backends
+// agree on a verdict, leaving the rest to be cancelled via routeCtx.
+func (rt *RouteTable) collectQuorum(i int, route Route, dispatched int, outcomes <-chan fanOutOutcome, merge MergeFunc, obs Observer) (*ReviewResult, error) {
+	quorum := route.QuorumSize
+	if quorum <= 0 {
+		quorum = dispatched/2 + 1
+	}
+
+	byVerdict := make(map[Verdict][]*ReviewResult)
+	for o := range outcomes {
+		obs.OnAttempt(AttemptInfo{RouteIndex: i, Backend: o.backend, Err: o.err})
+		if o.err != nil || o.result == nil {
+			continue
+		}
+		byVerdict[o.result.Verdict] = append(byVerdict[o.result.Verdict], o.result)
+		if len(byVerdict[o.result.Verdict]) >= quorum {
+			result := merge(byVerdict[o.result.Verdict])
+			obs.OnRouteResult(i, route.Backend, result)
+			return result, nil
+		}
+	}
+
+	err := fmt.Errorf("route %d: no quorum of %d reached across %d backends", i, quorum, dispatched)
+	obs.OnExhausted(i, route.Backend, route.FailMode, err)
+	return nil, err
+}
+
+// defaultMerge unions findings (deduped by File, Line, Message) and takes
+// the most severe verdict across results, per verdictRank.
+func defaultMerge(results []*ReviewResult) *ReviewResult {
+	type findingKey struct {
+		file    string
+		line    int
+		message string
+	}
+
+	seen := make(map[findingKey]bool)
+	var findings []Finding
+	var summaries []string
+	best := results[0].Verdict
+
+	for _, r := range results {
+		if verdictRank[r.Verdict] > verdictRank[best] {
+			best = r.Verdict
+		}
+		if r.Summary != "" {
+			summaries = append(summaries, r.Summary)
+		}
+		for _, f := range r.Findings {
+			k := findingKey{f.File, f.Line, f.Message}
+			if seen[k] {
+				continue
+			}
+			seen[k] = true
+			findings = append(findings, f)
+		}
+	}
+
+	return &ReviewResult{
+		Verdict:  best,
+		Findings: findings,
+		Summary:  strings.Join(summaries, "\n"),
+	}
+}
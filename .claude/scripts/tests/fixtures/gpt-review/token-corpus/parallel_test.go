@@ -0,0 +1,58 @@
+package router
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+// TestExecuteFanOutQuorumCancelsStragglers verifies that once a quorum
+// backend, executeFanOut returns without waiting on the still-running ones,
+// and that the routeCtx it used is cancelled promptly afterward so they
+// don't run to their full timeout.
+func TestExecuteFanOutQuorumCancelsStragglers(t *testing.T) {
+	rt := NewRouteTable(nil)
+
+	unblocked := make(chan struct{}, 2)
+
+	fast := func(ctx context.Context, input string) (*ReviewResult, error) {
+		return &ReviewResult{Verdict: VerdictApproved}, nil
+	}
+	straggler := func(ctx context.Context, input string) (*ReviewResult, error) {
+		<-ctx.Done()
+		unblocked <- struct{}{}
+		return nil, ctx.Err()
+	}
+
+	rt.RegisterBackend("fast", fast)
+	rt.RegisterBackend("straggler-1", straggler)
+	rt.RegisterBackend("straggler-2", straggler)
+
+	route := Route{
+		Backend:           "fast",
+		SecondaryBackends: []string{"straggler-1", "straggler-2"},
+		Strategy:          "quorum",
+		QuorumSize:        1,
+		Timeout:           time.Second,
+	}
+
+	start := time.Now()
+	result, err := rt.executeFanOut(context.Background(), 0, route, "input")
+	if err != nil {
+		t.Fatalf("executeFanOut: %v", err)
+	}
+	if result.Verdict != VerdictApproved {
+		t.Fatalf("verdict = %v, want %v", result.Verdict, VerdictApproved)
+	}
+	if elapsed := time.Since(start); elapsed > 200*time.Millisecond {
+		t.Fatalf("executeFanOut took %v to return after quorum was already reached", elapsed)
+	}
+
+	for i := 0; i < 2; i++ {
+		select {
+		case <-unblocked:
+		case <-time.After(time.Second):
+			t.Fatal("straggler backend was never unblocked by routeCtx cancellation")
+		}
+	}
+}
@@ -0,0 +1,172 @@
+package router
+
+import (
+	"context"
+	"fmt"
+	"sync"
+)
+
+// BackendEvent notifies a RouteTable that a provider-resolved backend
+// changed, so any cached BackendFunc for Name should be dropped.
+type BackendEvent struct {
+	Name    string
+	Removed bool
+	Updated bool
+}
+
+// BackendProvider resolves backend names that aren't pre-registered via
+// RegisterBackend, e.g. from Consul-style service discovery or a local
+// process registry, and reports changes through Watch.
+type BackendProvider interface {
+	Resolve(ctx context.Context, name string) (BackendFunc, error)
+	Watch(ctx context.Context) <-chan BackendEvent
+}
+
+// MultiProvider chains several BackendProviders, resolving from the first
+// one that succeeds and merging their Watch events onto a single channel.
+type MultiProvider struct {
+	Providers []BackendProvider
+}
+
+// Resolve tries each provider in order, returning the first successful
+// resolution.
+func (m *MultiProvider) Resolve(ctx context.Context, name string) (BackendFunc, error) {
+	var lastErr error
+	for _, p := range m.Providers {
+		fn, err := p.Resolve(ctx, name)
+		if err == nil && fn != nil {
+			return fn, nil
+		}
+		if err != nil {
+			lastErr = err
+		}
+	}
+	if lastErr == nil {
+		lastErr = fmt.Errorf("multi provider: no provider resolved backend %s", name)
+	}
+	return nil, lastErr
+}
+
+// Watch fans in every provider's event channel until ctx is cancelled.
+func (m *MultiProvider) Watch(ctx context.Context) <-chan BackendEvent {
+	out := make(chan BackendEvent)
+
+	var wg sync.WaitGroup
+	wg.Add(len(m.Providers))
+	for _, p := range m.Providers {
+		go func(p BackendProvider) {
+			defer wg.Done()
+			for event := range p.Watch(ctx) {
+				select {
+				case out <- event:
+				case <-ctx.Done():
+					return
+				}
+			}
+		}(p)
+	}
+
+	go func() {
+		wg.Wait()
+		close(out)
+	}()
+	return out
+}
+
+// staticProvider resolves backends from the table's RegisterBackend map. It
+// never emits BackendEvents since static registrations don't expire.
+type staticProvider struct {
+	rt *RouteTable
+}
+
+func (p *staticProvider) Resolve(ctx context.Context, name string) (BackendFunc, error) {
+	p.rt.mu.RLock()
+	fn, ok := p.rt.backends[name]
+	p.rt.mu.RUnlock()
+	if !ok {
+		return nil, fmt.Errorf("static provider: unknown backend %s", name)
+	}
+	return fn, nil
+}
+
+func (p *staticProvider) Watch(ctx context.Context) <-chan BackendEvent {
+	ch := make(chan BackendEvent)
+	go func() {
+		<-ctx.Done()
+		close(ch)
+	}()
+	return ch
+}
+
+// RegisterProvider adds a BackendProvider consulted whenever a backend name
+// isn't found in the static RegisterBackend map, and starts a watcher
+// goroutine that invalidates cached resolutions on each BackendEvent. The
+// watcher exits when RouteTable.Stop is called.
+func (rt *RouteTable) RegisterProvider(p BackendProvider) {
+	rt.mu.Lock()
+	rt.providers = append(rt.providers, p)
+	rt.mu.Unlock()
+
+	rt.wg.Add(1)
+	go rt.watchProvider(p)
+}
+
+func (rt *RouteTable) watchProvider(p BackendProvider) {
+	defer rt.wg.Done()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go func() {
+		select {
+		case <-rt.stopCh:
+			cancel()
+		case <-ctx.Done():
+		}
+	}()
+
+	for event := range p.Watch(ctx) {
+		rt.providerCacheMu.Lock()
+		delete(rt.providerCache, event.Name)
+		rt.providerCacheMu.Unlock()
+	}
+}
+
+// resolveBackend finds a BackendFunc for name: the static RegisterBackend
+// map first (so a static registration always wins, even one added after a
+// dynamic resolution for the same name was cached), then a cached provider
+// resolution, then each registered provider in turn. Non-static
+// resolutions are cached until invalidated by a BackendEvent.
+func (rt *RouteTable) resolveBackend(ctx context.Context, name string) (BackendFunc, bool) {
+	rt.mu.RLock()
+	fn, ok := rt.backends[name]
+	rt.mu.RUnlock()
+	if ok {
+		return fn, true
+	}
+
+	rt.providerCacheMu.Lock()
+	if fn, ok := rt.providerCache[name]; ok {
+		rt.providerCacheMu.Unlock()
+		return fn, true
+	}
+	rt.providerCacheMu.Unlock()
+
+	rt.mu.RLock()
+	providers := make([]BackendProvider, len(rt.providers))
+	copy(providers, rt.providers)
+	rt.mu.RUnlock()
+
+	for _, p := range providers {
+		fn, err := p.Resolve(ctx, name)
+		if err != nil || fn == nil {
+			continue
+		}
+		if _, isStatic := p.(*staticProvider); !isStatic {
+			rt.providerCacheMu.Lock()
+			rt.providerCache[name] = fn
+			rt.providerCacheMu.Unlock()
+		}
+		return fn, true
+	}
+	return nil, false
+}
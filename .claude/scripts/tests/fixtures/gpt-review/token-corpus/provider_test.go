@@ -0,0 +1,212 @@
+package router
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+)
+
+// fakeProvider resolves a backend whose result embeds the resolve count, so
+// a test can tell a fresh resolution apart from a cached one. Events pushed
+// onto its events channel are forwarded through Watch.
+type fakeProvider struct {
+	mu       sync.Mutex
+	resolves int
+	events   chan BackendEvent
+}
+
+func newFakeProvider() *fakeProvider {
+	return &fakeProvider{events: make(chan BackendEvent, 1)}
+}
+
+func (p *fakeProvider) Resolve(ctx context.Context, name string) (BackendFunc, error) {
+	p.mu.Lock()
+	p.resolves++
+	n := p.resolves
+	p.mu.Unlock()
+
+	return func(ctx context.Context, input string) (*ReviewResult, error) {
+		return &ReviewResult{Summary: fmt.Sprintf("resolution-%d", n)}, nil
+	}, nil
+}
+
+func (p *fakeProvider) Watch(ctx context.Context) <-chan BackendEvent {
+	out := make(chan BackendEvent)
+	go func() {
+		defer close(out)
+		for {
+			select {
+			case e := <-p.events:
+				select {
+				case out <- e:
+				case <-ctx.Done():
+					return
+				}
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	return out
+}
+
+func TestResolveBackendCacheInvalidatedByProviderEvent(t *testing.T) {
+	rt := NewRouteTable(nil)
+	p := newFakeProvider()
+	rt.RegisterProvider(p)
+	defer rt.Stop()
+
+	fn1, ok := rt.resolveBackend(context.Background(), "svc")
+	if !ok {
+		t.Fatal("expected resolveBackend to resolve svc")
+	}
+	r1, _ := fn1(context.Background(), "in")
+
+	fn2, ok := rt.resolveBackend(context.Background(), "svc")
+	if !ok {
+		t.Fatal("expected resolveBackend to resolve svc")
+	}
+	r2, _ := fn2(context.Background(), "in")
+	if r1.Summary != r2.Summary {
+		t.Fatalf("expected cached resolution to be reused: got %q then %q", r1.Summary, r2.Summary)
+	}
+
+	p.events <- BackendEvent{Name: "svc", Updated: true}
+
+	var r3 *ReviewResult
+	for deadline := time.Now().Add(time.Second); time.Now().Before(deadline); {
+		fn3, ok := rt.resolveBackend(context.Background(), "svc")
+		if !ok {
+			t.Fatal("expected resolveBackend to resolve svc")
+		}
+		r3, _ = fn3(context.Background(), "in")
+		if r3.Summary != r2.Summary {
+			return
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+	t.Fatalf("expected a BackendEvent to invalidate the provider cache and force a fresh resolution, still got %q", r3.Summary)
+}
+
+// stubProvider is a minimal BackendProvider for exercising MultiProvider:
+// Resolve either fails or returns a fixed, identifiable BackendFunc; Watch
+// emits from a fixed slice of events, one per call, then blocks until ctx
+// is cancelled.
+type stubProvider struct {
+	resolveErr error
+	tag        string
+	events     []BackendEvent
+}
+
+func (p *stubProvider) Resolve(ctx context.Context, name string) (BackendFunc, error) {
+	if p.resolveErr != nil {
+		return nil, p.resolveErr
+	}
+	tag := p.tag
+	return func(ctx context.Context, input string) (*ReviewResult, error) {
+		return &ReviewResult{Summary: tag}, nil
+	}, nil
+}
+
+func (p *stubProvider) Watch(ctx context.Context) <-chan BackendEvent {
+	out := make(chan BackendEvent)
+	go func() {
+		defer close(out)
+		for _, e := range p.events {
+			select {
+			case out <- e:
+			case <-ctx.Done():
+				return
+			}
+		}
+		<-ctx.Done()
+	}()
+	return out
+}
+
+func TestMultiProviderResolveTriesEachInOrder(t *testing.T) {
+	mp := &MultiProvider{Providers: []BackendProvider{
+		&stubProvider{resolveErr: fmt.Errorf("not found in provider 1")},
+		&stubProvider{tag: "from-provider-2"},
+	}}
+
+	fn, err := mp.Resolve(context.Background(), "svc")
+	if err != nil {
+		t.Fatalf("Resolve: %v", err)
+	}
+	result, _ := fn(context.Background(), "in")
+	if result.Summary != "from-provider-2" {
+		t.Fatalf("Resolve returned %q, want the second provider's resolution", result.Summary)
+	}
+
+	if _, err := (&MultiProvider{Providers: []BackendProvider{
+		&stubProvider{resolveErr: fmt.Errorf("boom")},
+	}}).Resolve(context.Background(), "svc"); err == nil {
+		t.Fatal("Resolve() = nil error when every provider failed, want an error")
+	}
+}
+
+func TestMultiProviderWatchFansInAndClosesOnCancel(t *testing.T) {
+	p1 := &stubProvider{tag: "p1", events: []BackendEvent{{Name: "a"}}}
+	p2 := &stubProvider{tag: "p2", events: []BackendEvent{{Name: "b"}, {Name: "c"}}}
+	mp := &MultiProvider{Providers: []BackendProvider{p1, p2}}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	events := mp.Watch(ctx)
+
+	seen := make(map[string]bool)
+	for i := 0; i < 3; i++ {
+		select {
+		case e := <-events:
+			seen[e.Name] = true
+		case <-time.After(time.Second):
+			t.Fatalf("timed out waiting for event %d", i)
+		}
+	}
+	for _, name := range []string{"a", "b", "c"} {
+		if !seen[name] {
+			t.Fatalf("expected Watch to fan in event %q from its providers, got %v", name, seen)
+		}
+	}
+
+	cancel()
+	select {
+	case _, ok := <-events:
+		if ok {
+			t.Fatal("expected Watch's channel to close once cancelled and drained")
+		}
+	case <-time.After(time.Second):
+		t.Fatal("Watch's channel never closed after ctx was cancelled")
+	}
+}
+
+func TestResolveBackendPrefersStaticOverStaleProviderCache(t *testing.T) {
+	rt := NewRouteTable(nil)
+	p := newFakeProvider()
+	rt.RegisterProvider(p)
+	defer rt.Stop()
+
+	fn, ok := rt.resolveBackend(context.Background(), "svc")
+	if !ok {
+		t.Fatal("expected resolveBackend to resolve svc via the provider")
+	}
+	cached, _ := fn(context.Background(), "in")
+	if cached.Summary != "resolution-1" {
+		t.Fatalf("expected the provider's resolution to be cached, got %q", cached.Summary)
+	}
+
+	rt.RegisterBackend("svc", func(ctx context.Context, input string) (*ReviewResult, error) {
+		return &ReviewResult{Summary: "static-registration"}, nil
+	})
+
+	fn, ok = rt.resolveBackend(context.Background(), "svc")
+	if !ok {
+		t.Fatal("expected resolveBackend to resolve svc")
+	}
+	result, _ := fn(context.Background(), "in")
+	if result.Summary != "static-registration" {
+		t.Fatalf("resolveBackend returned %q, want the static registration to win over the stale provider cache entry", result.Summary)
+	}
+}
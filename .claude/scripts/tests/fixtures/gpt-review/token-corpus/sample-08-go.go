@@ -9,12 +9,31 @@ import (
 
 // Route defines a single routing rule in the execution table.
 type Route struct {
-	Backend      string        `yaml:"backend"`
-	Conditions   []string      `yaml:"when"`
-	FailMode     string        `yaml:"fail_mode"`
-	Timeout      time.Duration `yaml:"timeout"`
-	Retries      int           `yaml:"retries"`
-	Capabilities []string      `yaml:"capabilities"`
+	Backend      string          `yaml:"backend"`
+	Conditions   []string        `yaml:"when"`
+	FailMode     string          `yaml:"fail_mode"`
+	Timeout      time.Duration   `yaml:"timeout"`
+	Retries      int             `yaml:"retries"`
+	Capabilities []string        `yaml:"capabilities"`
+	HealthCheck  *HealthCheck    `yaml:"health_check"`
+	Affinity     *AffinityConfig `yaml:"affinity"`
+
+	// Strategy selects how Execute fans a route out across backends:
+	// "" and "first_success" keep the original single-winner behavior,
+	// "parallel_all" runs every backend and merges all results, and
+	// "quorum" returns as soon as QuorumSize backends agree.
+	Strategy          string    `yaml:"strategy"`
+	SecondaryBackends []string  `yaml:"secondary_backends"`
+	QuorumSize        int       `yaml:"quorum_size"`
+	MergeFunc         MergeFunc `yaml:"-"`
+
+	// Cacheable opts a route into the table's ResultCache, if one is set
+	// via SetResultCache. CacheTTL is how long a result is cached for;
+	// CacheableVerdicts overrides which verdicts may be cached (default:
+	// every verdict except DECISION_NEEDED).
+	Cacheable         bool          `yaml:"cacheable"`
+	CacheTTL          time.Duration `yaml:"cache_ttl"`
+	CacheableVerdicts []Verdict     `yaml:"cacheable_verdicts"`
 }
 
 // Verdict represents the outcome of a review pass.
@@ -51,15 +70,101 @@ type RouteTable struct {
 	backends   map[string]BackendFunc
 	conditions map[string]func(ctx context.Context) bool
 	mu         sync.RWMutex
+
+	healthMu sync.Mutex
+	health   map[string]*backendHealth
+	stopCh   chan struct{}
+	stopOnce sync.Once
+	wg       sync.WaitGroup
+	started  bool
+
+	extractors map[string]KeyExtractor
+	affinityMu sync.Mutex
+	affinity   map[string]pinnedBackend
+
+	providers       []BackendProvider
+	providerCacheMu sync.Mutex
+	providerCache   map[string]BackendFunc
+
+	observer     Observer
+	cache        ResultCache
+	cacheKeyFunc CacheKeyFunc
 }
 
 // NewRouteTable creates a route table with the given routes.
 func NewRouteTable(routes []Route) *RouteTable {
-	return &RouteTable{
-		routes:     routes,
-		backends:   make(map[string]BackendFunc),
-		conditions: make(map[string]func(ctx context.Context) bool),
+	rt := &RouteTable{
+		routes:        routes,
+		backends:      make(map[string]BackendFunc),
+		conditions:    make(map[string]func(ctx context.Context) bool),
+		health:        make(map[string]*backendHealth),
+		stopCh:        make(chan struct{}),
+		extractors:    make(map[string]KeyExtractor),
+		affinity:      make(map[string]pinnedBackend),
+		providerCache: make(map[string]BackendFunc),
+	}
+	rt.providers = []BackendProvider{&staticProvider{rt: rt}}
+	return rt
+}
+
+// snapshotRoutes copies the route list under a read lock so Execute and the
+// fan-out helpers can iterate without holding rt.mu for the whole call.
+func (rt *RouteTable) snapshotRoutes() []Route {
+	rt.mu.RLock()
+	defer rt.mu.RUnlock()
+	routes := make([]Route, len(rt.routes))
+	copy(routes, rt.routes)
+	return routes
+}
+
+// Start launches the background health-check supervisor for every route
+// that declares a HealthCheck. It is a no-op to call Execute without ever
+// calling Start; routes without a HealthCheck are unaffected either way.
+// Backend providers registered via RegisterProvider watch for change events
+// independently of Start and are always active.
+func (rt *RouteTable) Start(ctx context.Context) error {
+	rt.mu.Lock()
+	if rt.started {
+		rt.mu.Unlock()
+		return fmt.Errorf("route table already started")
+	}
+
+	for i, route := range rt.routes {
+		if route.HealthCheck == nil {
+			continue
+		}
+		if route.HealthCheck.Interval <= 0 {
+			rt.mu.Unlock()
+			return fmt.Errorf("route %d: health check Interval must be > 0", i)
+		}
+		if route.HealthCheck.Timeout <= 0 {
+			rt.mu.Unlock()
+			return fmt.Errorf("route %d: health check Timeout must be > 0", i)
+		}
+	}
+
+	rt.started = true
+	rt.mu.Unlock()
+
+	for _, route := range rt.routes {
+		if route.HealthCheck == nil {
+			continue
+		}
+		rt.wg.Add(1)
+		go rt.runHealthCheck(ctx, route)
 	}
+	return nil
+}
+
+// Stop signals all background goroutines (health checks, provider watchers,
+// credential renewers) to exit and waits for them to drain. It is safe to
+// call Stop on a table that was never started, and safe to call more than
+// once.
+func (rt *RouteTable) Stop() {
+	rt.stopOnce.Do(func() {
+		close(rt.stopCh)
+	})
+	rt.wg.Wait()
 }
 
 // RegisterBackend adds a named backend handler.
@@ -71,41 +176,82 @@ func (rt *RouteTable) RegisterBackend(name string, fn BackendFunc) {
 
 // Execute runs through routes in order, returning the first successful result.
 func (rt *RouteTable) Execute(ctx context.Context, input string) (*ReviewResult, error) {
-	rt.mu.RLock()
-	defer rt.mu.RUnlock()
+	routes := rt.snapshotRoutes()
 
-	for i, route := range rt.routes {
+	obs := rt.observerOrNoop()
+
+	for i, route := range routes {
 		if !rt.evaluateConditions(ctx, route.Conditions) {
 			continue
 		}
 
-		fn, ok := rt.backends[route.Backend]
+		if cached, ok := rt.cacheLookup(i, route, input); ok {
+			return cached, nil
+		}
+
+		if route.Strategy == "parallel_all" || route.Strategy == "quorum" {
+			result, err := rt.executeFanOut(ctx, i, route, input)
+			if err != nil {
+				if route.FailMode == "hard_fail" {
+					return nil, err
+				}
+				continue
+			}
+			rt.cacheStore(i, route, input, result)
+			return result, nil
+		}
+
+		backendName := route.Backend
+		if pinned, ok := rt.affinityPin(ctx, route, input); ok {
+			if _, exists := rt.resolveBackend(ctx, pinned); exists && rt.allowAttempt(pinned, route.HealthCheck) {
+				backendName = pinned
+			}
+		}
+
+		fn, ok := rt.resolveBackend(ctx, backendName)
 		if !ok {
 			if route.FailMode == "hard_fail" {
-				return nil, fmt.Errorf("unknown backend: %s", route.Backend)
+				return nil, fmt.Errorf("unknown backend: %s", backendName)
 			}
 			continue
 		}
 
+		if !rt.allowAttempt(backendName, route.HealthCheck) {
+			if route.FailMode == "hard_fail" {
+				return nil, fmt.Errorf("backend %s is unhealthy", backendName)
+			}
+			continue
+		}
+
+		obs.OnRouteStart(i, backendName)
+
 		var result *ReviewResult
 		var err error
 		for attempt := 0; attempt <= route.Retries; attempt++ {
+			start := time.Now()
 			routeCtx, cancel := context.WithTimeout(ctx, route.Timeout)
 			result, err = fn(routeCtx, input)
 			cancel()
+			latency := time.Since(start)
+			rt.recordResult(backendName, route.HealthCheck, err)
+			obs.OnAttempt(AttemptInfo{RouteIndex: i, Backend: backendName, Attempt: attempt, Latency: latency, Err: err})
 			if err == nil {
 				break
 			}
-			fmt.Printf("[route %d] attempt %d failed: %v\n", i, attempt+1, err)
+			obs.OnRetry(i, backendName, attempt+1, err)
 		}
 
 		if err != nil {
+			obs.OnExhausted(i, backendName, route.FailMode, err)
 			if route.FailMode == "hard_fail" {
-				return nil, fmt.Errorf("backend %s hard-failed: %w", route.Backend, err)
+				return nil, fmt.Errorf("backend %s hard-failed: %w", backendName, err)
 			}
 			continue
 		}
 
+		obs.OnRouteResult(i, backendName, result)
+		rt.cacheStore(i, route, input, result)
+		rt.pinAffinity(ctx, route, input, backendName)
 		return result, nil
 	}
 
@@ -114,7 +260,9 @@ func (rt *RouteTable) Execute(ctx context.Context, input string) (*ReviewResult,
 
 func (rt *RouteTable) evaluateConditions(ctx context.Context, conds []string) bool {
 	for _, name := range conds {
+		rt.mu.RLock()
 		fn, ok := rt.conditions[name]
+		rt.mu.RUnlock()
 		if !ok || !fn(ctx) {
 			return false
 		}